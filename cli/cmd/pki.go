@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/defenseunicorns/zarf/cli/internal/utils/pki"
+)
+
+var pkiHost string
+var pkiCAKeyPassphrase string
+var pkiWatchInterval time.Duration
+
+var pkiCmd = &cobra.Command{
+	Use:   "pki",
+	Short: "Tools for managing the Zarf PKI (CA and server certificates)",
+}
+
+var pkiRenewCmd = &cobra.Command{
+	Use:   "renew",
+	Short: "Re-sign the server certificate from the existing Zarf CA and reapply the tls-pem secret",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := pki.RenewServerCert(cmd.Context(), pkiHost, pkiCAKeyPassphrase); err != nil {
+			logrus.Fatal(err)
+		}
+	},
+}
+
+var pkiWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Run in the foreground, automatically renewing the server certificate when it nears expiry",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		pki.WatchAndRenew(ctx, pkiHost, pkiCAKeyPassphrase, pkiWatchInterval)
+	},
+}
+
+var pkiRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Replace the Zarf CA, cross-signing it with the outgoing CA for a rotation grace period",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := pki.RotateCA(cmd.Context(), pkiHost, pkiCAKeyPassphrase); err != nil {
+			logrus.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pkiCmd)
+	pkiCmd.AddCommand(pkiRenewCmd)
+	pkiCmd.AddCommand(pkiWatchCmd)
+	pkiCmd.AddCommand(pkiRotateCmd)
+
+	pkiRenewCmd.Flags().StringVar(&pkiHost, "host", "", "the host/IP the renewed server certificate should cover")
+	_ = pkiRenewCmd.MarkFlagRequired("host")
+	pkiRenewCmd.Flags().StringVar(&pkiCAKeyPassphrase, "ca-key-passphrase", "", "passphrase for the CA key, if it was generated with one")
+
+	pkiWatchCmd.Flags().StringVar(&pkiHost, "host", "", "the host/IP the renewed server certificate should cover")
+	_ = pkiWatchCmd.MarkFlagRequired("host")
+	pkiWatchCmd.Flags().StringVar(&pkiCAKeyPassphrase, "ca-key-passphrase", "", "passphrase for the CA key, if it was generated with one")
+	pkiWatchCmd.Flags().DurationVar(&pkiWatchInterval, "check-interval", time.Hour, "how often to check the server certificate's expiry")
+
+	pkiRotateCmd.Flags().StringVar(&pkiHost, "host", "", "the host/IP the rolled-over server certificate should cover")
+	_ = pkiRotateCmd.MarkFlagRequired("host")
+	pkiRotateCmd.Flags().StringVar(&pkiCAKeyPassphrase, "ca-key-passphrase", "", "passphrase for the outgoing CA key, if it was generated with one")
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// pkiFieldManager is the field manager used for Server-Side Apply of PKI-owned resources,
+// so renewals and rotations never stomp on fields another controller is managing.
+const pkiFieldManager = "zarf-pki"
+
+// kubeClient returns a client-go Clientset built from the local kubeconfig.
+func kubeClient() (*kubernetes.Clientset, error) {
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{},
+	).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load kubernetes config: %w", err)
+	}
+
+	return kubernetes.NewForConfig(config)
+}
+
+// PushTLSSecret performs a Server-Side Apply of a kubernetes.io/tls Secret containing
+// certPEM/keyPEM, creating or updating it atomically. This replaces the previous
+// delete-then-create pair of kubectl shell-outs, which raced and required kubectl to be
+// installed on the host.
+func PushTLSSecret(ctx context.Context, namespace, name string, certPEM, keyPEM []byte) error {
+	client, err := kubeClient()
+	if err != nil {
+		return err
+	}
+
+	secretType := corev1.SecretTypeTLS
+	apply := corev1apply.Secret(name, namespace).
+		WithType(secretType).
+		WithData(map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		})
+
+	_, err = client.CoreV1().Secrets(namespace).Apply(ctx, apply, metav1.ApplyOptions{
+		FieldManager: pkiFieldManager,
+		Force:        true,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to apply %s/%s secret: %w", namespace, name, err)
+	}
+
+	return nil
+}
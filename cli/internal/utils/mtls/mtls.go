@@ -0,0 +1,61 @@
+// Package mtls issues and verifies per-identity client certificates signed by the Zarf CA,
+// so in-cluster components (the internal git server, the registry) can authenticate to
+// each other without sharing a single basic-auth password.
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"path/filepath"
+
+	"github.com/defenseunicorns/zarf/cli/internal/utils"
+)
+
+// ClientCertificate issues a client-auth certificate for name from the Zarf CA and
+// returns it as a tls.Certificate ready to use in a tls.Config's Certificates field.
+func ClientCertificate(name string) (tls.Certificate, error) {
+	certPEM, keyPEM, err := utils.GenerateClientCert(name)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// VerifyPeerCertificate returns a tls.Config.VerifyPeerCertificate callback that verifies
+// the presented certificate chains up to the Zarf CA and is valid for extKeyUsage, rather
+// than relying on the default trust store. It is meant for use in http.Transport's
+// TLSClientConfig (or a server's ClientAuth config) when dialing other in-cluster
+// components over mTLS.
+func VerifyPeerCertificate(extKeyUsage x509.ExtKeyUsage) (func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error, error) {
+	directory := utils.AssetPath("certs")
+	caFile := filepath.Join(directory, "zarf-ca.pem")
+
+	ca, err := utils.LoadCertificate(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load Zarf CA, run `zarf init` first: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("no peer certificate presented")
+		}
+
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return fmt.Errorf("unable to parse peer certificate: %w", err)
+		}
+
+		opts := x509.VerifyOptions{
+			Roots:     pool,
+			KeyUsages: []x509.ExtKeyUsage{extKeyUsage},
+		}
+
+		_, err = cert.Verify(opts)
+		return err
+	}, nil
+}
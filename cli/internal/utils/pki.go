@@ -1,6 +1,11 @@
 package utils
 
 import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -11,9 +16,16 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	// github.com/youmark/pkcs8 is a new dependency, added deliberately: the stdlib's
+	// x509.EncryptPEMBlock is deprecated and only ever offered weak PKCS#1-style
+	// encryption, and the stdlib has no built-in encrypted-PKCS#8 support. This is the
+	// most widely used Go implementation of RFC 5958 password-based PKCS#8 encryption.
+	// Needs a `go get`/go.mod+go.sum update to land alongside this change.
+	"github.com/youmark/pkcs8"
 )
 
 // Based off of https://github.com/dmcgowan/quicktls/blob/master/main.go
@@ -25,7 +37,14 @@ const org = "Zarf Utility Cluster"
 // 13 months is the max length allowed by browsers
 const validFor = time.Hour * 24 * 375
 
-// Very limited special chars for git / basic auth 
+// Supported PKIOptions.KeyType values
+const (
+	KeyTypeRSA     = "rsa"
+	KeyTypeECDSA   = "ecdsa"
+	KeyTypeEd25519 = "ed25519"
+)
+
+// Very limited special chars for git / basic auth
 // https://owasp.org/www-community/password-special-characters has complete list of safe chars
 const randomStringChars = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ!~-"
 
@@ -43,21 +62,142 @@ func RandomString(length int) string {
 	return string(bytes)
 }
 
-// GeneratePKI create a CA and signed server keypair
-func GeneratePKI(host string) {
+// PKIOptions controls how GeneratePKI mints the CA and server keypair, allowing
+// callers to pick a key algorithm and override the defaults that used to be
+// hardcoded (RSA-2048, 13 month validity, single host).
+type PKIOptions struct {
+	// KeyType selects the private key algorithm: "rsa" (default), "ecdsa", or "ed25519".
+	KeyType string
+	// RSABits is the key size used when KeyType is "rsa". Defaults to rsaBits.
+	RSABits int
+	// ECDSACurve selects the curve used when KeyType is "ecdsa": P224, P256 (default), P384, or P521.
+	ECDSACurve string
+	// ValidFor overrides how long the CA and server cert remain valid. Defaults to validFor.
+	ValidFor time.Duration
+	// Organization overrides the Subject.Organization on generated certificates. Defaults to org.
+	Organization string
+	// CommonName overrides the Subject.CommonName on the server certificate.
+	CommonName string
+	// Hosts is the list of SANs (IPs and/or DNS names) to include on the server certificate.
+	// If empty, the host passed to GeneratePKI is used.
+	Hosts []string
+	// StartDate overrides the certificate NotBefore. Defaults to time.Now().
+	StartDate time.Time
+	// CAFile, if set, points to an existing CA certificate (PEM) to sign the server cert
+	// with instead of minting a fresh self-signed CA. CAKeyFile must also be set.
+	CAFile string
+	// CAKeyFile, if set, points to the PEM-encoded private key (PKCS#1 or PKCS#8) for CAFile.
+	CAKeyFile string
+	// Intermediates is a list of additional PEM-encoded intermediate certificate files, in
+	// issuance order, to append to fullchain.pem after the leaf and the issuing CA.
+	Intermediates []string
+	// CAKeyPassphrase, if set, PKCS#8-encrypts the persisted CA private key file
+	// (zarf-ca-key.pem) with this passphrase instead of writing it in plaintext. The same
+	// passphrase must be supplied to LoadCAWithPassphrase by anything that renews or rotates
+	// later (RenewServerCert, RotateCA). GenerateClientCert takes no passphrase and only
+	// reads an unencrypted CA key.
+	CAKeyPassphrase string
+}
+
+// defaultPKIOptions returns the historical defaults (RSA-2048, 13 months).
+func defaultPKIOptions() PKIOptions {
+	return PKIOptions{
+		KeyType:  KeyTypeRSA,
+		RSABits:  rsaBits,
+		ValidFor: validFor,
+	}
+}
+
+// withDefaults fills in any zero-valued fields of opts with the historical defaults.
+func (opts PKIOptions) withDefaults() PKIOptions {
+	defaults := defaultPKIOptions()
+
+	if opts.KeyType == "" {
+		opts.KeyType = defaults.KeyType
+	}
+	if opts.RSABits == 0 {
+		opts.RSABits = defaults.RSABits
+	}
+	if opts.ValidFor == 0 {
+		opts.ValidFor = defaults.ValidFor
+	}
+	if opts.Organization == "" {
+		opts.Organization = org
+	}
+	if opts.StartDate.IsZero() {
+		opts.StartDate = time.Now()
+	}
+
+	return opts
+}
+
+// GeneratePKI creates a CA and signed server keypair and pushes the resulting tls-pem
+// secret into kube-system.
+func GeneratePKI(ctx context.Context, host string) error {
+	return GeneratePKIWithOptions(ctx, host, PKIOptions{})
+}
+
+// GeneratePKIWithOptions creates a CA and signed server keypair using the given
+// PKIOptions, falling back to the historical RSA-2048 / 13-month defaults for any
+// fields left unset, and pushes the resulting tls-pem secret into kube-system.
+func GeneratePKIWithOptions(ctx context.Context, host string, opts PKIOptions) error {
+	opts = opts.withDefaults()
+	if len(opts.Hosts) == 0 {
+		opts.Hosts = []string{host}
+	}
+
 	directory := AssetPath("certs")
 
 	CreateDirectory(directory, 0700)
+
+	// RenewServerCert, RotateCA, and GenerateClientCert all load the CA from these fixed
+	// paths, so a caller-supplied CA needs a local copy here too, not just the paths it
+	// was loaded from.
 	caFile := filepath.Join(directory, "zarf-ca.pem")
-	ca, caKey, err := generateCA(caFile, validFor)
-	if err != nil {
-		logrus.Fatal(err)
+	caKeyFile := filepath.Join(directory, "zarf-ca-key.pem")
+
+	var ca *x509.Certificate
+	var caKey crypto.Signer
+	externalCA := opts.CAFile != ""
+
+	if externalCA {
+		var err error
+		ca, caKey, err = loadExternalCA(opts.CAFile, opts.CAKeyFile)
+		if err != nil {
+			return fmt.Errorf("unable to load externally provided CA: %w", err)
+		}
+
+		if err := writeCertificatePEM(ca, caFile); err != nil {
+			return fmt.Errorf("unable to cache externally provided CA certificate: %w", err)
+		}
+	} else {
+		var err error
+		ca, caKey, err = generateCA(caFile, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Persist the CA key (mode 0600) so later renewals and intermediate signing don't
+	// require minting a brand new, untrusted CA (or re-reading the caller's original key
+	// file). Optionally PKCS#8-encrypt it at rest with opts.CAKeyPassphrase.
+	if opts.CAKeyPassphrase != "" {
+		if err := savePrivateKeyWithPassphrase(caKey, caKeyFile, opts.CAKeyPassphrase); err != nil {
+			return err
+		}
+	} else if err := savePrivateKey(caKey, caKeyFile); err != nil {
+		return err
 	}
 
 	hostCert := filepath.Join(directory, "zarf-server.crt")
 	hostKey := filepath.Join(directory, "zarf-server.key")
-	if err := generateCert(host, hostCert, hostKey, ca, caKey, validFor); err != nil {
-		logrus.Fatal(err)
+	if err := generateCert(opts, hostCert, hostKey, ca, caKey); err != nil {
+		return err
+	}
+
+	fullchainFile := filepath.Join(directory, "fullchain.pem")
+	if err := writeFullChain(fullchainFile, hostCert, ca, opts.Intermediates); err != nil {
+		return err
 	}
 
 	publicKeyBlock := pem.Block{
@@ -67,18 +207,116 @@ func GeneratePKI(host string) {
 
 	publicKeyPem := string(pem.EncodeToMemory(&publicKeyBlock))
 
-	// Push the certs to the cluster
-	ExecCommand([]string{}, "/usr/local/bin/kubectl", "-n", "kube-system", "delete", "secret", "tls-pem", "--ignore-not-found")
-	ExecCommand([]string{}, "/usr/local/bin/kubectl", "-n", "kube-system", "create", "secret", "tls", "tls-pem", "--cert="+directory+"/zarf-server.crt", "--key="+directory+"/zarf-server.key")
+	certPEM, err := os.ReadFile(hostCert)
+	if err != nil {
+		return err
+	}
+	keyPEM, err := os.ReadFile(hostKey)
+	if err != nil {
+		return err
+	}
+
+	if err := PushTLSSecret(ctx, "kube-system", "tls-pem", certPEM, keyPEM); err != nil {
+		return err
+	}
 
-	fmt.Println("Ephemeral CA below and saved to " + caFile + "\n")
-	fmt.Println(publicKeyPem)
+	if externalCA {
+		fmt.Println("Using externally provided CA, cached at " + caFile)
+	} else {
+		fmt.Println("Ephemeral CA below and saved to " + caFile + "\n")
+		fmt.Println(publicKeyPem)
+	}
+
+	return nil
+}
+
+// loadExternalCA parses an existing CA certificate and private key from disk so
+// GeneratePKIWithOptions can sign the server cert under an enterprise root instead of
+// always minting a fresh "Zarf Private Certificate Authority".
+func loadExternalCA(caFile, caKeyFile string) (*x509.Certificate, crypto.Signer, error) {
+	certBytes, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	certBlock, _ := pem.Decode(certBytes)
+	if certBlock == nil {
+		return nil, nil, fmt.Errorf("no PEM data found in %s", caFile)
+	}
+	ca, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ca.IsCA {
+		return nil, nil, fmt.Errorf("%s is not a CA certificate", caFile)
+	}
+	if ca.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return nil, nil, fmt.Errorf("%s does not have the certSign key usage", caFile)
+	}
+
+	keyBytes, err := os.ReadFile(caKeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBlock, _ := pem.Decode(keyBytes)
+	if keyBlock == nil {
+		return nil, nil, fmt.Errorf("no PEM data found in %s", caKeyFile)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes); err == nil {
+		return ca, key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse %s as a PKCS#1 or PKCS#8 private key: %w", caKeyFile, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("key in %s is not a signing key", caKeyFile)
+	}
+
+	return ca, signer, nil
+}
+
+// writeFullChain writes the leaf certificate at hostCertFile, followed by the issuing CA
+// and any additional intermediate certificates, into chainFile. This is what most ingress
+// controllers and gRPC clients expect instead of a bare leaf certificate.
+func writeFullChain(chainFile, hostCertFile string, ca *x509.Certificate, intermediates []string) error {
+	leafPEM, err := os.ReadFile(hostCertFile)
+	if err != nil {
+		return err
+	}
+
+	chainOut, err := os.Create(chainFile)
+	if err != nil {
+		return err
+	}
+	defer chainOut.Close()
+
+	if _, err := chainOut.Write(leafPEM); err != nil {
+		return err
+	}
+	if err := pem.Encode(chainOut, &pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}); err != nil {
+		return err
+	}
+
+	for _, intermediateFile := range intermediates {
+		intermediatePEM, err := os.ReadFile(intermediateFile)
+		if err != nil {
+			return err
+		}
+		if _, err := chainOut.Write(intermediatePEM); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // newCertificate creates a new template
-func newCertificate(validFor time.Duration) *x509.Certificate {
-	notBefore := time.Now()
-	notAfter := notBefore.Add(validFor)
+func newCertificate(opts PKIOptions) *x509.Certificate {
+	notBefore := opts.StartDate
+	notAfter := notBefore.Add(opts.ValidFor)
 
 	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
 	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
@@ -86,35 +324,81 @@ func newCertificate(validFor time.Duration) *x509.Certificate {
 		logrus.Fatalf("failed to generate serial number: %s", err)
 	}
 
+	// KeyEncipherment (RSA key transport) is only meaningful for RSA keys; setting it on an
+	// ECDSA or Ed25519 leaf is invalid and strict verifiers reject it.
+	keyUsage := x509.KeyUsageDigitalSignature
+	switch strings.ToLower(opts.KeyType) {
+	case "", KeyTypeRSA:
+		keyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
 	return &x509.Certificate{
 		SerialNumber: serialNumber,
 		Subject: pkix.Name{
-			Organization: []string{org},
+			Organization: []string{opts.Organization},
+			CommonName:   opts.CommonName,
 		},
 		NotBefore: notBefore,
 		NotAfter:  notAfter,
 
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		KeyUsage:              keyUsage,
 		BasicConstraintsValid: true,
 	}
 }
 
-// newPrivateKey creates a new private key
-func newPrivateKey() (*rsa.PrivateKey, error) {
-	return rsa.GenerateKey(rand.Reader, rsaBits)
+// newPrivateKey creates a new private key using the algorithm selected by opts.KeyType.
+// It returns the key as a crypto.Signer so callers can generate certificates without
+// caring whether the underlying key is RSA, ECDSA, or Ed25519.
+func newPrivateKey(opts PKIOptions) (crypto.Signer, error) {
+	switch strings.ToLower(opts.KeyType) {
+	case "", KeyTypeRSA:
+		bits := opts.RSABits
+		if bits == 0 {
+			bits = rsaBits
+		}
+		return rsa.GenerateKey(rand.Reader, bits)
+	case KeyTypeECDSA:
+		curve, err := ecdsaCurve(opts.ECDSACurve)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	case KeyTypeEd25519:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		return priv, err
+	default:
+		return nil, fmt.Errorf("unsupported PKI key type: %s", opts.KeyType)
+	}
+}
+
+// ecdsaCurve maps a PKIOptions.ECDSACurve string to the corresponding elliptic.Curve,
+// defaulting to P256 when unset.
+func ecdsaCurve(name string) (elliptic.Curve, error) {
+	switch strings.ToUpper(name) {
+	case "", "P256":
+		return elliptic.P256(), nil
+	case "P224":
+		return elliptic.P224(), nil
+	case "P384":
+		return elliptic.P384(), nil
+	case "P521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported ECDSA curve: %s", name)
+	}
 }
 
 // generateCA creates a new CA certificate, saves the certificate
 // and returns the x509 certificate and crypto private key. This
 // private key should never be saved to disk, but rather used to
 // immediately generate further certificates.
-func generateCA(caFile string, validFor time.Duration) (*x509.Certificate, *rsa.PrivateKey, error) {
-	template := newCertificate(validFor)
+func generateCA(caFile string, opts PKIOptions) (*x509.Certificate, crypto.Signer, error) {
+	template := newCertificate(opts)
 	template.IsCA = true
 	template.KeyUsage |= x509.KeyUsageCertSign
 	template.Subject.CommonName = "Zarf Private Certificate Authority"
 
-	priv, err := newPrivateKey()
+	priv, err := newPrivateKey(opts)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -129,35 +413,138 @@ func generateCA(caFile string, validFor time.Duration) (*x509.Certificate, *rsa.
 		return nil, nil, err
 	}
 
-	certOut, err := os.Create(caFile)
-	if err != nil {
+	if err := writeCertificatePEM(ca, caFile); err != nil {
 		return nil, nil, err
 	}
+
+	return ca, priv, nil
+}
+
+// writeCertificatePEM PEM-encodes cert and writes it to certFile.
+func writeCertificatePEM(cert *x509.Certificate, certFile string) error {
+	certOut, err := os.Create(certFile)
+	if err != nil {
+		return err
+	}
 	defer certOut.Close()
-	if err := pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes}); err != nil {
+
+	return pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+// GenerateCA mints a new self-signed CA certificate and key pair, writing the certificate
+// to caFile. It is exported so sibling packages such as utils/pki can mint a replacement
+// CA as part of rotation without duplicating the template/key-generation logic.
+func GenerateCA(caFile string, opts PKIOptions) (*x509.Certificate, crypto.Signer, error) {
+	return generateCA(caFile, opts.withDefaults())
+}
+
+// NewCATemplateAndKey builds an unsigned CA certificate template and its private key,
+// without signing or writing anything to disk. It is exported so utils/pki.RotateCA can
+// sign the same template/key pair twice - once self-signed, once cross-signed by the
+// outgoing CA - to produce a trust chain that works during a rotation grace period.
+func NewCATemplateAndKey(opts PKIOptions) (*x509.Certificate, crypto.Signer, error) {
+	opts = opts.withDefaults()
+
+	template := newCertificate(opts)
+	template.IsCA = true
+	template.KeyUsage |= x509.KeyUsageCertSign
+	template.Subject.CommonName = "Zarf Private Certificate Authority"
+
+	priv, err := newPrivateKey(opts)
+	if err != nil {
 		return nil, nil, err
 	}
 
-	return ca, priv, nil
+	return template, priv, nil
+}
+
+// SignCertificate signs template with parentKey, using parent as the issuer (pass template
+// itself as parent for a self-signed certificate), and returns the parsed result without
+// writing it to disk.
+func SignCertificate(template, parent *x509.Certificate, key, parentKey crypto.Signer) (*x509.Certificate, error) {
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParseCertificate(derBytes)
+}
+
+// WritePEMCertificate PEM-encodes cert and writes it to certFile.
+func WritePEMCertificate(cert *x509.Certificate, certFile string) error {
+	return writeCertificatePEM(cert, certFile)
+}
+
+// SavePrivateKey writes key to keyFile in the appropriate PEM format. It is exported so
+// sibling packages such as utils/pki can persist keys (e.g. a freshly rotated CA key)
+// using the same encoding rules as GeneratePKI.
+func SavePrivateKey(key crypto.Signer, keyFile string) error {
+	return savePrivateKey(key, keyFile)
+}
+
+// LoadCertificate reads and parses a single PEM-encoded certificate from certFile.
+func LoadCertificate(certFile string) (*x509.Certificate, error) {
+	certBytes, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(certBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", certFile)
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// GenerateSignedCert issues a new leaf certificate for opts.Hosts from the given CA and
+// writes it (and its private key) to certFile/keyFile. It is exported so sibling packages
+// such as utils/pki can re-sign leaves from a CA loaded via LoadCA without duplicating the
+// template/key-generation logic.
+func GenerateSignedCert(opts PKIOptions, certFile string, keyFile string, ca *x509.Certificate, caKey crypto.Signer) error {
+	return generateCert(opts.withDefaults(), certFile, keyFile, ca, caKey)
 }
 
-// generateCert generates a new certificate for the given host using the
+// generateCert generates a new certificate for the hosts in opts.Hosts using the
 // provided certificate authority. The cert and key files are stored in the
 // the provided files.
-func generateCert(host string, certFile string, keyFile string, ca *x509.Certificate, caKey *rsa.PrivateKey, validFor time.Duration) error {
-	template := newCertificate(validFor)
+func generateCert(opts PKIOptions, certFile string, keyFile string, ca *x509.Certificate, caKey crypto.Signer) error {
+	template := newCertificate(opts)
+
+	hasDNSHost := false
+	seenDNSNames := map[string]bool{}
+	for _, host := range opts.Hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+			continue
+		}
 
-	if ip := net.ParseIP(host); ip != nil {
-		template.IPAddresses = append(template.IPAddresses, ip)
-	} else {
-		// Add localhost to make things cleaner
-		template.DNSNames = append(template.DNSNames, host, "localhost", "*.localhost")
+		hasDNSHost = true
+		if seenDNSNames[host] {
+			continue
+		}
+		seenDNSNames[host] = true
+
+		template.DNSNames = append(template.DNSNames, host)
 		if template.Subject.CommonName == "" {
 			template.Subject.CommonName = host
 		}
 	}
 
-	priv, err := newPrivateKey()
+	// Add localhost to make things cleaner, but only alongside an actual DNS host (as
+	// before multi-host support) and only if it isn't already one of opts.Hosts.
+	if hasDNSHost {
+		for _, name := range []string{"localhost", "*.localhost"} {
+			if !seenDNSNames[name] {
+				seenDNSNames[name] = true
+				template.DNSNames = append(template.DNSNames, name)
+			}
+		}
+	}
+
+	template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageServerAuth)
+
+	priv, err := newPrivateKey(opts)
 	if err != nil {
 		return err
 	}
@@ -165,9 +552,50 @@ func generateCert(host string, certFile string, keyFile string, ca *x509.Certifi
 	return generateFromTemplate(certFile, keyFile, template, ca, priv, caKey)
 }
 
+// GenerateClientCert issues a client-auth leaf certificate for name from the Zarf CA
+// persisted by GeneratePKI(WithOptions), returning the PEM-encoded cert and key rather
+// than writing them to disk. This lets in-cluster components (the internal git server,
+// the registry) authenticate to each other with per-identity client certificates instead
+// of sharing a single basic-auth password generated by RandomString. The CA key is read
+// with LoadCA, so a CA key persisted with a CAKeyPassphrase cannot be loaded and an error
+// is returned.
+func GenerateClientCert(name string) (certPEM, keyPEM []byte, err error) {
+	directory := AssetPath("certs")
+	caFile := filepath.Join(directory, "zarf-ca.pem")
+	caKeyFile := filepath.Join(directory, "zarf-ca-key.pem")
+
+	ca, caKey, err := LoadCA(caFile, caKeyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load Zarf CA, run `zarf init` first: %w", err)
+	}
+
+	opts := PKIOptions{CommonName: name}.withDefaults()
+	template := newCertificate(opts)
+	template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageClientAuth)
+
+	priv, err := newPrivateKey(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, ca, priv.Public(), caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+
+	keyPEM, err = marshalPrivateKeyPEM(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return certPEM, keyPEM, nil
+}
+
 // generateFromTemplate generates a certificate from the given template and signed by
 // the given parent, storing the results in a certificate and key file.
-func generateFromTemplate(certFile, keyFile string, template, parent *x509.Certificate, key *rsa.PrivateKey, parentKey *rsa.PrivateKey) error {
+func generateFromTemplate(certFile, keyFile string, template, parent *x509.Certificate, key crypto.Signer, parentKey crypto.Signer) error {
 	derBytes, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
 	if err != nil {
 		return err
@@ -183,16 +611,149 @@ func generateFromTemplate(certFile, keyFile string, template, parent *x509.Certi
 	return savePrivateKey(key, keyFile)
 }
 
-// savePrivateKey saves the private key to a PEM file
-func savePrivateKey(key *rsa.PrivateKey, keyFile string) error {
+// savePrivateKey saves the private key to a PEM file, choosing the PEM block type
+// (and PKCS#1 vs PKCS#8 encoding) based on the concrete key type.
+func savePrivateKey(key crypto.Signer, keyFile string) error {
 	keyOut, err := os.OpenFile(keyFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return err
 	}
 	defer keyOut.Close()
 
-	keyBytes := x509.MarshalPKCS1PrivateKey(key)
-	pem.Encode(keyOut, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: keyBytes})
+	keyPEM, err := marshalPrivateKeyPEM(key)
+	if err != nil {
+		return err
+	}
 
-	return nil
-}
\ No newline at end of file
+	_, err = keyOut.Write(keyPEM)
+	return err
+}
+
+// savePrivateKeyWithPassphrase saves key to a PEM file (mode 0600) the same way as
+// savePrivateKey, but PKCS#8-encrypts it with passphrase first (PEM type
+// "ENCRYPTED PRIVATE KEY"). x509.EncryptPEMBlock is deprecated and was never more than
+// PKCS#1-style encryption, so we go straight to PKCS#8 here.
+func savePrivateKeyWithPassphrase(key crypto.Signer, keyFile, passphrase string) error {
+	keyOut, err := os.OpenFile(keyFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer keyOut.Close()
+
+	keyBytes, err := pkcs8.MarshalPrivateKeyWithPassword(key, []byte(passphrase), nil)
+	if err != nil {
+		return err
+	}
+
+	return pem.Encode(keyOut, &pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: keyBytes})
+}
+
+// marshalPrivateKeyPEM PEM-encodes key, choosing the PEM block type (and PKCS#1 vs
+// PKCS#8 encoding) based on the concrete key type.
+func marshalPrivateKeyPEM(key crypto.Signer) ([]byte, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		keyBytes := x509.MarshalPKCS1PrivateKey(k)
+		return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: keyBytes}), nil
+	case *ecdsa.PrivateKey:
+		keyBytes, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), nil
+	case ed25519.PrivateKey:
+		keyBytes, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}), nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// LoadPrivateKey reads a PEM-encoded, unencrypted private key previously written by
+// savePrivateKey and returns it as a crypto.Signer, regardless of whether it is RSA,
+// ECDSA, or Ed25519. For a key written by savePrivateKeyWithPassphrase, use
+// LoadPrivateKeyWithPassphrase instead.
+func LoadPrivateKey(keyFile string) (crypto.Signer, error) {
+	return LoadPrivateKeyWithPassphrase(keyFile, "")
+}
+
+// LoadPrivateKeyWithPassphrase reads a PEM-encoded private key previously written by
+// savePrivateKey or savePrivateKeyWithPassphrase and returns it as a crypto.Signer.
+// passphrase is only used (and must be correct) when the key is PKCS#8-encrypted; pass ""
+// for a plaintext key.
+func LoadPrivateKeyWithPassphrase(keyFile, passphrase string) (crypto.Signer, error) {
+	keyBytes, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", keyFile)
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("key in %s is not a signing key", keyFile)
+		}
+		return signer, nil
+	case "ENCRYPTED PRIVATE KEY":
+		key, err := pkcs8.ParsePKCS8PrivateKeyWithPassword(block.Bytes, []byte(passphrase))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decrypt %s, check the passphrase: %w", keyFile, err)
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("key in %s is not a signing key", keyFile)
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key block type %q in %s", block.Type, keyFile)
+	}
+}
+
+// LoadCA reads the CA certificate and an unencrypted private key previously written by
+// GeneratePKI(WithOptions) so that renewals and intermediate signing can reuse the same
+// trust root. For a CA key persisted with a CAKeyPassphrase, use LoadCAWithPassphrase.
+func LoadCA(caFile, caKeyFile string) (*x509.Certificate, crypto.Signer, error) {
+	return LoadCAWithPassphrase(caFile, caKeyFile, "")
+}
+
+// LoadCAWithPassphrase is LoadCA for a CA key that was persisted encrypted via
+// PKIOptions.CAKeyPassphrase; pass the same passphrase here, or "" for an unencrypted key.
+func LoadCAWithPassphrase(caFile, caKeyFile, passphrase string) (*x509.Certificate, crypto.Signer, error) {
+	certBytes, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, _ := pem.Decode(certBytes)
+	if block == nil {
+		return nil, nil, fmt.Errorf("no PEM data found in %s", caFile)
+	}
+
+	ca, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	caKey, err := LoadPrivateKeyWithPassphrase(caKeyFile, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ca, caKey, nil
+}
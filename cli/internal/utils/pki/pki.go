@@ -0,0 +1,291 @@
+// Package pki tracks the CA and server certificate files created by utils.GeneratePKI
+// and supports rotating them in place, without tearing down the cluster.
+package pki
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/defenseunicorns/zarf/cli/internal/utils"
+)
+
+// renewalThreshold is how close to expiry a certificate must be before RenewServerCert
+// is triggered automatically by a renewal watcher.
+const renewalThreshold = 30 * 24 * time.Hour
+
+// Paths describes where the CA and server cert/key files created by utils.GeneratePKI live.
+type Paths struct {
+	Directory  string
+	CAFile     string
+	CAKeyFile  string
+	ServerCert string
+	ServerKey  string
+}
+
+// DefaultPaths returns the Paths for the standard certs directory used by utils.GeneratePKI.
+func DefaultPaths() Paths {
+	directory := utils.AssetPath("certs")
+	return Paths{
+		Directory:  directory,
+		CAFile:     filepath.Join(directory, "zarf-ca.pem"),
+		CAKeyFile:  filepath.Join(directory, "zarf-ca-key.pem"),
+		ServerCert: filepath.Join(directory, "zarf-server.crt"),
+		ServerKey:  filepath.Join(directory, "zarf-server.key"),
+	}
+}
+
+// RenewServerCert re-signs a new leaf certificate for host from the existing CA (loaded
+// from paths.CAFile/paths.CAKeyFile) and reapplies the tls-pem secret in kube-system. The
+// key algorithm and SANs of the existing leaf (paths.ServerCert) are carried over rather
+// than reset to the RSA-2048/single-host defaults, so a cert originally minted with a
+// different key type or with multiple SANs (see utils.PKIOptions.Hosts) isn't downgraded
+// or narrowed on renewal. caKeyPassphrase must match whatever PKIOptions.CAKeyPassphrase
+// the CA key was persisted with, or be "" for an unencrypted key.
+func RenewServerCert(ctx context.Context, host, caKeyPassphrase string) error {
+	paths := DefaultPaths()
+
+	ca, caKey, err := utils.LoadCAWithPassphrase(paths.CAFile, paths.CAKeyFile, caKeyPassphrase)
+	if err != nil {
+		return fmt.Errorf("unable to load existing CA, run `zarf init` first: %w", err)
+	}
+
+	opts, err := carryOverCertOptions(paths.ServerCert, host)
+	if err != nil {
+		return fmt.Errorf("unable to read existing server certificate: %w", err)
+	}
+
+	if err := utils.GenerateSignedCert(opts, paths.ServerCert, paths.ServerKey, ca, caKey); err != nil {
+		return fmt.Errorf("unable to renew server certificate: %w", err)
+	}
+
+	if err := pushTLSSecret(ctx, paths); err != nil {
+		return fmt.Errorf("unable to reapply tls-pem secret: %w", err)
+	}
+
+	logrus.Info("renewed server certificate for " + host)
+
+	return nil
+}
+
+// carryOverCertOptions reads the existing certificate at certFile and returns PKIOptions
+// that reissue it with the same key algorithm (including RSA key size or ECDSA curve) and
+// SANs, adding host if it isn't already
+// covered. localhost/*.localhost are dropped here since generateCert re-adds them itself.
+func carryOverCertOptions(certFile, host string) (utils.PKIOptions, error) {
+	cert, err := utils.LoadCertificate(certFile)
+	if err != nil {
+		return utils.PKIOptions{}, err
+	}
+
+	opts := utils.PKIOptions{
+		KeyType:    keyTypeOf(cert),
+		CommonName: cert.Subject.CommonName,
+	}
+	if len(cert.Subject.Organization) > 0 {
+		opts.Organization = cert.Subject.Organization[0]
+	}
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		opts.RSABits = pub.N.BitLen()
+	case *ecdsa.PublicKey:
+		// Curve names are "P-256" etc.; utils.PKIOptions.ECDSACurve expects "P256".
+		opts.ECDSACurve = strings.ReplaceAll(pub.Curve.Params().Name, "-", "")
+	}
+
+	seen := map[string]bool{host: true}
+	hosts := []string{host}
+	for _, name := range cert.DNSNames {
+		if name == "localhost" || name == "*.localhost" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		hosts = append(hosts, name)
+	}
+	for _, ip := range cert.IPAddresses {
+		addr := ip.String()
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		hosts = append(hosts, addr)
+	}
+	opts.Hosts = hosts
+
+	return opts, nil
+}
+
+// keyTypeOf maps an existing certificate's public key back to the utils.PKIOptions.KeyType
+// that would regenerate the same algorithm.
+func keyTypeOf(cert *x509.Certificate) string {
+	switch cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		return utils.KeyTypeECDSA
+	case ed25519.PublicKey:
+		return utils.KeyTypeEd25519
+	default:
+		return utils.KeyTypeRSA
+	}
+}
+
+// RotateCA creates a new CA, cross-signs it with the outgoing CA's key for a grace period,
+// and rolls the leaf certificate over to be signed by the new CA. The cross-signed CA
+// certificate is served alongside the leaf (in fullchain.pem) so that peers who still only
+// trust the old CA can verify it via leaf -> crossSignedCA -> oldCA, while peers who have
+// already picked up the new (self-signed) CA verify directly against it. The old CA remains
+// on disk under a ".old" suffix for the duration of the grace period. caKeyPassphrase
+// decrypts the outgoing CA key; the replacement CA key is always persisted unencrypted.
+func RotateCA(ctx context.Context, host, caKeyPassphrase string) error {
+	paths := DefaultPaths()
+
+	oldCA, oldCAKey, err := utils.LoadCAWithPassphrase(paths.CAFile, paths.CAKeyFile, caKeyPassphrase)
+	if err != nil {
+		return fmt.Errorf("unable to load existing CA, run `zarf init` first: %w", err)
+	}
+
+	// Preserve the outgoing CA under a recognizable name for the grace period so that
+	// peers who haven't picked up the new CA yet still trust certs it has already issued.
+	if err := utils.CopyFile(paths.CAFile, paths.CAFile+".old"); err != nil {
+		return fmt.Errorf("unable to archive outgoing CA: %w", err)
+	}
+
+	newCATemplate, newCAKey, err := utils.NewCATemplateAndKey(utils.PKIOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to generate replacement CA key: %w", err)
+	}
+
+	newCA, err := utils.SignCertificate(newCATemplate, newCATemplate, newCAKey, newCAKey)
+	if err != nil {
+		return fmt.Errorf("unable to self-sign replacement CA: %w", err)
+	}
+
+	// Cross-sign the same key/subject with the outgoing CA so certs issued under the new CA
+	// remain verifiable by peers who still only trust the old one.
+	crossSignedCA, err := utils.SignCertificate(newCATemplate, oldCA, newCAKey, oldCAKey)
+	if err != nil {
+		return fmt.Errorf("unable to cross-sign replacement CA: %w", err)
+	}
+
+	if err := utils.WritePEMCertificate(newCA, paths.CAFile); err != nil {
+		return fmt.Errorf("unable to install new CA: %w", err)
+	}
+	if err := utils.SavePrivateKey(newCAKey, paths.CAKeyFile); err != nil {
+		return fmt.Errorf("unable to persist new CA key: %w", err)
+	}
+	crossSignedFile := paths.CAFile + ".crosssigned"
+	if err := utils.WritePEMCertificate(crossSignedCA, crossSignedFile); err != nil {
+		return fmt.Errorf("unable to persist cross-signed CA: %w", err)
+	}
+
+	if err := RenewServerCert(ctx, host, ""); err != nil {
+		return fmt.Errorf("unable to roll leaf certificate to new CA: %w", err)
+	}
+
+	if err := writeGracePeriodChain(paths, crossSignedCA, oldCA); err != nil {
+		return fmt.Errorf("unable to write grace-period fullchain.pem: %w", err)
+	}
+
+	// RenewServerCert already pushed a leaf-only tls-pem secret above; replace it with the
+	// full leaf -> crossSignedCA -> oldCA chain so peers who still only trust the old CA can
+	// validate what's actually being served, not just what's on disk.
+	if err := pushGracePeriodChain(ctx, paths); err != nil {
+		return fmt.Errorf("unable to push grace-period chain to tls-pem secret: %w", err)
+	}
+
+	logrus.Info("rotated Zarf CA; serving a cross-signed chain for the grace period, old CA retained at " + paths.CAFile + ".old")
+
+	return nil
+}
+
+// writeGracePeriodChain writes fullchain.pem as leaf -> crossSignedCA -> oldCA, so that
+// peers who trust either the new (via crossSignedCA's subject/key) or the old CA can
+// validate certificates issued immediately after a rotation.
+func writeGracePeriodChain(paths Paths, crossSignedCA, oldCA *x509.Certificate) error {
+	leafPEM, err := os.ReadFile(paths.ServerCert)
+	if err != nil {
+		return err
+	}
+
+	chainOut, err := os.Create(filepath.Join(paths.Directory, "fullchain.pem"))
+	if err != nil {
+		return err
+	}
+	defer chainOut.Close()
+
+	if _, err := chainOut.Write(leafPEM); err != nil {
+		return err
+	}
+	if err := pem.Encode(chainOut, &pem.Block{Type: "CERTIFICATE", Bytes: crossSignedCA.Raw}); err != nil {
+		return err
+	}
+
+	return pem.Encode(chainOut, &pem.Block{Type: "CERTIFICATE", Bytes: oldCA.Raw})
+}
+
+// pushTLSSecret reapplies the tls-pem secret in kube-system from the current server cert/key.
+func pushTLSSecret(ctx context.Context, paths Paths) error {
+	certPEM, err := os.ReadFile(paths.ServerCert)
+	if err != nil {
+		return err
+	}
+	keyPEM, err := os.ReadFile(paths.ServerKey)
+	if err != nil {
+		return err
+	}
+
+	return utils.PushTLSSecret(ctx, "kube-system", "tls-pem", certPEM, keyPEM)
+}
+
+// pushGracePeriodChain reapplies the tls-pem secret using fullchain.pem (leaf ->
+// crossSignedCA -> oldCA) as tls.crt, instead of the bare leaf, so the in-cluster TLS
+// endpoint actually serves a chain peers on the old CA can validate during the grace period.
+func pushGracePeriodChain(ctx context.Context, paths Paths) error {
+	chainPEM, err := os.ReadFile(filepath.Join(paths.Directory, "fullchain.pem"))
+	if err != nil {
+		return err
+	}
+	keyPEM, err := os.ReadFile(paths.ServerKey)
+	if err != nil {
+		return err
+	}
+
+	return utils.PushTLSSecret(ctx, "kube-system", "tls-pem", chainPEM, keyPEM)
+}
+
+// WatchAndRenew runs in the background, checking the server certificate's NotAfter and
+// calling RenewServerCert when less than renewalThreshold remains. It blocks until ctx is
+// canceled, so callers should run it in a goroutine.
+func WatchAndRenew(ctx context.Context, host, caKeyPassphrase string, checkInterval time.Duration) {
+	paths := DefaultPaths()
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cert, err := utils.LoadCertificate(paths.ServerCert)
+			if err != nil {
+				logrus.Warn("pki watcher: unable to load server certificate: " + err.Error())
+				continue
+			}
+
+			if time.Until(cert.NotAfter) < renewalThreshold {
+				if err := RenewServerCert(ctx, host, caKeyPassphrase); err != nil {
+					logrus.Warn("pki watcher: unable to renew server certificate: " + err.Error())
+				}
+			}
+		}
+	}
+}
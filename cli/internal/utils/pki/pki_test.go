@@ -0,0 +1,120 @@
+package pki
+
+import (
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/cli/internal/utils"
+)
+
+// assertVerifies fails t unless leaf verifies against root, using bridge (if non-nil) as
+// an intermediate - i.e. leaf -> bridge -> root.
+func assertVerifies(t *testing.T, label string, leaf, root, bridge *x509.Certificate) {
+	t.Helper()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+
+	opts := x509.VerifyOptions{Roots: roots}
+	if bridge != nil {
+		intermediates := x509.NewCertPool()
+		intermediates.AddCert(bridge)
+		opts.Intermediates = intermediates
+	}
+
+	if _, err := leaf.Verify(opts); err != nil {
+		t.Errorf("leaf does not verify against %s: %v", label, err)
+	}
+}
+
+// TestCarryOverCertOptionsPreservesKeyTypeAndSANs guards against the RenewServerCert
+// regression where every renewal silently reset the leaf to RSA-2048/single-host,
+// downgrading and narrowing certs originally minted with a different key type or with
+// multiple SANs.
+func TestCarryOverCertOptionsPreservesKeyTypeAndSANs(t *testing.T) {
+	caTemplate, caKey, err := utils.NewCATemplateAndKey(utils.PKIOptions{})
+	if err != nil {
+		t.Fatalf("NewCATemplateAndKey: %v", err)
+	}
+	ca, err := utils.SignCertificate(caTemplate, caTemplate, caKey, caKey)
+	if err != nil {
+		t.Fatalf("SignCertificate: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+
+	original := utils.PKIOptions{
+		KeyType: utils.KeyTypeECDSA,
+		Hosts:   []string{"10.0.0.1", "api.example.com"},
+	}
+	if err := utils.GenerateSignedCert(original, certFile, keyFile, ca, caKey); err != nil {
+		t.Fatalf("GenerateSignedCert: %v", err)
+	}
+
+	renewed, err := carryOverCertOptions(certFile, "api.example.com")
+	if err != nil {
+		t.Fatalf("carryOverCertOptions: %v", err)
+	}
+
+	if renewed.KeyType != utils.KeyTypeECDSA {
+		t.Errorf("KeyType = %q, want %q", renewed.KeyType, utils.KeyTypeECDSA)
+	}
+
+	want := map[string]bool{"10.0.0.1": true, "api.example.com": true}
+	if len(renewed.Hosts) != len(want) {
+		t.Fatalf("Hosts = %v, want %d entries matching %v", renewed.Hosts, len(want), want)
+	}
+	for _, h := range renewed.Hosts {
+		if !want[h] {
+			t.Errorf("unexpected carried-over host %q", h)
+		}
+		if h == "localhost" || h == "*.localhost" {
+			t.Errorf("localhost SANs should not be carried over, generateCert re-adds them")
+		}
+	}
+}
+
+// TestCrossSignedChainVerifiesAgainstBothRoots exercises the exact cross-signing math
+// RotateCA relies on: a leaf issued under the new CA must verify both directly against
+// the new (self-signed) CA and, via the cross-signed bridge certificate, against the
+// outgoing CA that peers may still only trust during the grace period.
+func TestCrossSignedChainVerifiesAgainstBothRoots(t *testing.T) {
+	oldCATemplate, oldCAKey, err := utils.NewCATemplateAndKey(utils.PKIOptions{})
+	if err != nil {
+		t.Fatalf("NewCATemplateAndKey(old): %v", err)
+	}
+	oldCA, err := utils.SignCertificate(oldCATemplate, oldCATemplate, oldCAKey, oldCAKey)
+	if err != nil {
+		t.Fatalf("SignCertificate(old): %v", err)
+	}
+
+	newCATemplate, newCAKey, err := utils.NewCATemplateAndKey(utils.PKIOptions{})
+	if err != nil {
+		t.Fatalf("NewCATemplateAndKey(new): %v", err)
+	}
+	newCA, err := utils.SignCertificate(newCATemplate, newCATemplate, newCAKey, newCAKey)
+	if err != nil {
+		t.Fatalf("SignCertificate(new, self-signed): %v", err)
+	}
+	crossSignedCA, err := utils.SignCertificate(newCATemplate, oldCA, newCAKey, oldCAKey)
+	if err != nil {
+		t.Fatalf("SignCertificate(new, cross-signed): %v", err)
+	}
+
+	dir := t.TempDir()
+	leafCertFile := filepath.Join(dir, "leaf.crt")
+	leafKeyFile := filepath.Join(dir, "leaf.key")
+	if err := utils.GenerateSignedCert(utils.PKIOptions{Hosts: []string{"example.com"}}, leafCertFile, leafKeyFile, newCA, newCAKey); err != nil {
+		t.Fatalf("GenerateSignedCert: %v", err)
+	}
+	leaf, err := utils.LoadCertificate(leafCertFile)
+	if err != nil {
+		t.Fatalf("LoadCertificate: %v", err)
+	}
+
+	assertVerifies(t, "new CA directly", leaf, newCA, nil)
+	assertVerifies(t, "old CA via cross-signed bridge", leaf, oldCA, crossSignedCA)
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"crypto"
+	"path/filepath"
+	"testing"
+)
+
+// keyEqualer is satisfied by *rsa.PublicKey, *ecdsa.PublicKey, and ed25519.PublicKey, all of
+// which implement Equal(crypto.PublicKey) bool.
+type keyEqualer interface {
+	Equal(x crypto.PublicKey) bool
+}
+
+func TestSavePrivateKeyRoundTrip(t *testing.T) {
+	for _, keyType := range []string{KeyTypeRSA, KeyTypeECDSA, KeyTypeEd25519} {
+		keyType := keyType
+		t.Run(keyType, func(t *testing.T) {
+			key, err := newPrivateKey(PKIOptions{KeyType: keyType, RSABits: 2048}.withDefaults())
+			if err != nil {
+				t.Fatalf("newPrivateKey(%s): %v", keyType, err)
+			}
+
+			keyFile := filepath.Join(t.TempDir(), "key.pem")
+			if err := savePrivateKey(key, keyFile); err != nil {
+				t.Fatalf("savePrivateKey: %v", err)
+			}
+
+			loaded, err := LoadPrivateKeyWithPassphrase(keyFile, "")
+			if err != nil {
+				t.Fatalf("LoadPrivateKeyWithPassphrase: %v", err)
+			}
+
+			equaler, ok := loaded.Public().(keyEqualer)
+			if !ok || !equaler.Equal(key.Public()) {
+				t.Fatalf("%s: round-tripped public key does not match original", keyType)
+			}
+		})
+	}
+}
+
+func TestSavePrivateKeyWithPassphraseRoundTrip(t *testing.T) {
+	key, err := newPrivateKey(PKIOptions{KeyType: KeyTypeECDSA}.withDefaults())
+	if err != nil {
+		t.Fatalf("newPrivateKey: %v", err)
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	if err := savePrivateKeyWithPassphrase(key, keyFile, "correct horse battery staple"); err != nil {
+		t.Fatalf("savePrivateKeyWithPassphrase: %v", err)
+	}
+
+	if _, err := LoadPrivateKeyWithPassphrase(keyFile, "wrong passphrase"); err == nil {
+		t.Fatal("expected an error loading an encrypted key with the wrong passphrase")
+	}
+
+	loaded, err := LoadPrivateKeyWithPassphrase(keyFile, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("LoadPrivateKeyWithPassphrase: %v", err)
+	}
+
+	equaler, ok := loaded.Public().(keyEqualer)
+	if !ok || !equaler.Equal(key.Public()) {
+		t.Fatal("round-tripped public key does not match original")
+	}
+}